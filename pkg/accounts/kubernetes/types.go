@@ -0,0 +1,91 @@
+package kubernetes
+
+import (
+	"github.com/armory/spinnaker-operator/pkg/apis/spinnaker/interfaces"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+)
+
+// Account is a single kubernetes account entry under
+// spec.spinnakerConfig.config.providers.kubernetes.accounts
+type Account struct {
+	Name string `json:"name,omitempty"`
+	// Settings holds the provider-specific freeform settings for this account (context, user,
+	// cluster, namespaces, auth-mode-specific blocks, ...)
+	Settings map[string]interface{} `json:"-"`
+	Auth     *Auth                  `json:"auth,omitempty"`
+}
+
+// Auth configures how a rest.Config is built to validate (and, for clouddriver, reach) this
+// account's cluster. Exactly one of these should be set.
+type Auth struct {
+	KubeconfigFile    string                                 `json:"kubeconfigFile,omitempty"`
+	Kubeconfig        *clientcmdv1.Config                    `json:"kubeconfig,omitempty"`
+	KubeconfigSecret  *interfaces.SecretInNamespaceReference `json:"kubeconfigSecret,omitempty"`
+	UseServiceAccount bool                                   `json:"useServiceAccount,omitempty"`
+	// VaultKubernetes mints short-lived kubernetes credentials from Vault's kubernetes secrets
+	// engine instead of requiring a kubeconfig at all
+	VaultKubernetes *vaultKubernetesSettings `json:"vaultKubernetes,omitempty"`
+	// Sources merges multiple kubeconfig sources (KUBECONFIG-style) into one before building the
+	// rest.Config, so a shared cluster/CA block can live in one source while per-account user
+	// credentials come from another
+	Sources []authSource `json:"sources,omitempty"`
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *Account) DeepCopyInto(out *Account) {
+	*out = *in
+	if in.Settings != nil {
+		out.Settings = make(map[string]interface{}, len(in.Settings))
+		for k, v := range in.Settings {
+			out.Settings[k] = v
+		}
+	}
+	if in.Auth != nil {
+		out.Auth = new(Auth)
+		in.Auth.DeepCopyInto(out.Auth)
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver
+func (in *Account) DeepCopy() *Account {
+	if in == nil {
+		return nil
+	}
+	out := new(Account)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *Auth) DeepCopyInto(out *Auth) {
+	*out = *in
+	if in.Kubeconfig != nil {
+		out.Kubeconfig = in.Kubeconfig.DeepCopy()
+	}
+	if in.KubeconfigSecret != nil {
+		out.KubeconfigSecret = in.KubeconfigSecret.DeepCopy()
+	}
+	if in.VaultKubernetes != nil {
+		v := *in.VaultKubernetes
+		if in.VaultKubernetes.ClusterCAData != nil {
+			v.ClusterCAData = append([]byte(nil), in.VaultKubernetes.ClusterCAData...)
+		}
+		out.VaultKubernetes = &v
+	}
+	if in.Sources != nil {
+		out.Sources = make([]authSource, len(in.Sources))
+		for i := range in.Sources {
+			in.Sources[i].DeepCopyInto(&out.Sources[i])
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver
+func (in *Auth) DeepCopy() *Auth {
+	if in == nil {
+		return nil
+	}
+	out := new(Auth)
+	in.DeepCopyInto(out)
+	return out
+}
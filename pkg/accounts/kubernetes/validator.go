@@ -2,12 +2,20 @@ package kubernetes
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
 	tools "github.com/armory/go-yaml-tools/pkg/secrets"
 	"github.com/armory/spinnaker-operator/pkg/apis/spinnaker/interfaces"
@@ -16,7 +24,11 @@ import (
 	"github.com/armory/spinnaker-operator/pkg/util"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -24,6 +36,8 @@ import (
 	clientcmdlatest "k8s.io/client-go/tools/clientcmd/api/latest"
 	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
 	certutil "k8s.io/client-go/util/cert"
+	certcsr "k8s.io/client-go/util/certificate/csr"
+	"k8s.io/client-go/util/keyutil"
 	"k8s.io/klog"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -75,48 +89,79 @@ func (k *kubernetesAccountValidator) makeClient(ctx context.Context, spinSvc int
 		return makeClientFromSecretRef(ctx, auth.KubeconfigSecret, aSettings)
 	}
 	if auth.UseServiceAccount {
-		return makeClientFromServiceAccount(ctx, spinSvc, c)
+		return makeClientFromServiceAccount(ctx, k.account.Name, spinSvc, c, aSettings)
+	}
+	if auth.VaultKubernetes != nil {
+		return makeClientFromVaultKubernetes(ctx, k.account.Name, auth.VaultKubernetes, aSettings)
+	}
+	if len(auth.Sources) > 0 {
+		return makeClientFromSources(ctx, auth.Sources, aSettings, spinSvc.GetSpinnakerConfig())
 	}
 	return nil, noAuthProvidedError
 }
 
-// makeClientFromFile loads the client config from a file path which can be a secret
-func makeClientFromFile(ctx context.Context, file string, settings authSettings, spinCfg *interfaces.SpinnakerConfig) (*rest.Config, error) {
-	var cfg *clientcmdapi.Config
-	var kubeconfigBytes []byte
-	var err error
+// loadKubeconfigBytes reads the raw kubeconfig content referenced by file, which can be a
+// secret reference, an absolute path already decoded by a secret engine, or a relative path
+// into spec.spinnakerConfig.files.
+func loadKubeconfigBytes(ctx context.Context, file string, spinCfg *interfaces.SpinnakerConfig) ([]byte, error) {
 	if tools.IsEncryptedSecret(file) {
 		f, err := secrets.DecodeAsFile(ctx, file)
 		if err != nil {
 			return nil, fmt.Errorf("error decoding kubeconfigFile from secret reference \"%s\":\n  %w", file, err)
 		}
-		kubeconfigBytes, err = ioutil.ReadFile(f)
+		b, err := ioutil.ReadFile(f)
 		if err != nil {
 			return nil, fmt.Errorf("error loading kubeconfigFile \"%s\":\n  %w", f, err)
 		}
-	} else if filepath.IsAbs(file) {
+		return b, nil
+	}
+	if filepath.IsAbs(file) {
 		// if file path is absolute, it may already be a path decoded by secret engines
-		kubeconfigBytes, err = ioutil.ReadFile(file)
+		b, err := ioutil.ReadFile(file)
 		if err != nil {
 			return nil, fmt.Errorf("error loading kubeconfigFile \"%s\":\n  %w", file, err)
 		}
-	} else {
-		// we're taking relative file paths as files defined inside spec.spinnakerConfig.files
-		kubeconfigBytes = spinCfg.GetFileContent(file)
+		return b, nil
+	}
+	// we're taking relative file paths as files defined inside spec.spinnakerConfig.files
+	return spinCfg.GetFileContent(file), nil
+}
+
+// loadRawConfigFromFile loads and parses the kubeconfig referenced by file, without applying
+// any authSettings overrides.
+func loadRawConfigFromFile(ctx context.Context, file string, spinCfg *interfaces.SpinnakerConfig) (*clientcmdapi.Config, error) {
+	kubeconfigBytes, err := loadKubeconfigBytes(ctx, file, spinCfg)
+	if err != nil {
+		return nil, err
 	}
-	cfg, err = clientcmd.Load(kubeconfigBytes)
+	cfg, err := clientcmd.Load(kubeconfigBytes)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing kubeconfigFile:\n  %w", err)
 	}
-	restCfg, err := clientcmd.NewDefaultClientConfig(*cfg, makeOverrideFromAuthSettings(cfg, settings)).ClientConfig()
+	return cfg, nil
+}
+
+// makeClientFromFile loads the client config from a file path which can be a secret
+func makeClientFromFile(ctx context.Context, file string, settings authSettings, spinCfg *interfaces.SpinnakerConfig) (*rest.Config, error) {
+	cfg, err := loadRawConfigFromFile(ctx, file, spinCfg)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := makeOverrideFromAuthSettings(cfg, settings)
+	if err != nil {
+		return nil, err
+	}
+	restCfg, err := clientcmd.NewDefaultClientConfig(*cfg, overrides).ClientConfig()
 	if err != nil {
 		return restCfg, fmt.Errorf("error building rest config from kubeconfigFile:\n  %w", err)
 	}
+	applyImpersonation(restCfg, settings)
 	return restCfg, nil
 }
 
-// makeClientFromSecretRef reads the client config from a Kubernetes secret in the current context's namespace
-func makeClientFromSecretRef(ctx context.Context, ref *interfaces.SecretInNamespaceReference, settings authSettings) (*rest.Config, error) {
+// loadRawConfigFromSecretRef reads and parses the kubeconfig stored in a Kubernetes secret in
+// the current context's namespace, without applying any authSettings overrides.
+func loadRawConfigFromSecretRef(ctx context.Context, ref *interfaces.SecretInNamespaceReference) (*clientcmdapi.Config, error) {
 	sc, err := secrets.FromContextWithError(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to make kubeconfig file")
@@ -129,23 +174,146 @@ func makeClientFromSecretRef(ctx context.Context, ref *interfaces.SecretInNamesp
 	if err != nil {
 		return nil, err
 	}
-
 	cfg, err := config.RawConfig()
 	if err != nil {
 		return nil, fmt.Errorf("error parsing kubeconfigFile:\n  %w", err)
 	}
-	return clientcmd.NewDefaultClientConfig(cfg, makeOverrideFromAuthSettings(&cfg, settings)).ClientConfig()
+	return &cfg, nil
+}
+
+// makeClientFromSecretRef reads the client config from a Kubernetes secret in the current context's namespace
+func makeClientFromSecretRef(ctx context.Context, ref *interfaces.SecretInNamespaceReference, settings authSettings) (*rest.Config, error) {
+	cfg, err := loadRawConfigFromSecretRef(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := makeOverrideFromAuthSettings(cfg, settings)
+	if err != nil {
+		return nil, err
+	}
+	restCfg, err := clientcmd.NewDefaultClientConfig(*cfg, overrides).ClientConfig()
+	if err != nil {
+		return restCfg, err
+	}
+	applyImpersonation(restCfg, settings)
+	return restCfg, nil
+}
+
+// loadRawConfigFromConfigAPI converts the v1 Config (the usual format for kubeconfig) inlined
+// into the CRD into the internal clientcmdapi representation.
+func loadRawConfigFromConfigAPI(config *clientcmdv1.Config) (*clientcmdapi.Config, error) {
+	cfg := clientcmdapi.NewConfig()
+	if err := clientcmdlatest.Scheme.Convert(config, cfg, nil); err != nil {
+		return nil, fmt.Errorf("error converting inlined kubeconfig:\n  %w", err)
+	}
+	return cfg, nil
 }
 
 // makeClientFromConfigAPI makes a client config from the v1 Config (the usual format for kubeconfig) inlined
 // into the CRD.
 func makeClientFromConfigAPI(config *clientcmdv1.Config, settings authSettings) (*rest.Config, error) {
-	cfg := clientcmdapi.NewConfig()
-	if err := clientcmdlatest.Scheme.Convert(config, cfg, nil); err != nil {
-		return nil, nil
+	cfg, err := loadRawConfigFromConfigAPI(config)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := makeOverrideFromAuthSettings(cfg, settings)
+	if err != nil {
+		return nil, err
+	}
+	restCfg, err := clientcmd.NewDefaultClientConfig(*cfg, overrides).ClientConfig()
+	if err != nil {
+		return restCfg, err
+	}
+	applyImpersonation(restCfg, settings)
+	return restCfg, nil
+}
+
+// authSource is one entry in auth.Sources: exactly one of its fields should be set, and it is
+// loaded the same way the corresponding single-source Auth field would be.
+type authSource struct {
+	KubeconfigFile   string                                 `json:"kubeconfigFile,omitempty"`
+	Kubeconfig       *clientcmdv1.Config                    `json:"kubeconfig,omitempty"`
+	KubeconfigSecret *interfaces.SecretInNamespaceReference `json:"kubeconfigSecret,omitempty"`
+}
+
+// DeepCopyInto copies the receiver into out
+func (s *authSource) DeepCopyInto(out *authSource) {
+	*out = *s
+	if s.Kubeconfig != nil {
+		out.Kubeconfig = s.Kubeconfig.DeepCopy()
+	}
+	if s.KubeconfigSecret != nil {
+		out.KubeconfigSecret = s.KubeconfigSecret.DeepCopy()
 	}
+}
 
-	return clientcmd.NewDefaultClientConfig(*cfg, makeOverrideFromAuthSettings(cfg, settings)).ClientConfig()
+// loadRawConfig loads the raw clientcmdapi.Config for a single authSource, without applying
+// any authSettings overrides.
+func (s authSource) loadRawConfig(ctx context.Context, spinCfg *interfaces.SpinnakerConfig) (*clientcmdapi.Config, error) {
+	if s.KubeconfigFile != "" {
+		return loadRawConfigFromFile(ctx, s.KubeconfigFile, spinCfg)
+	}
+	if s.Kubeconfig != nil {
+		return loadRawConfigFromConfigAPI(s.Kubeconfig)
+	}
+	if s.KubeconfigSecret != nil {
+		return loadRawConfigFromSecretRef(ctx, s.KubeconfigSecret)
+	}
+	return nil, fmt.Errorf("auth source has none of kubeconfigFile, kubeconfig or kubeconfigSecret set")
+}
+
+// mergeConfigs merges a list of kubeconfigs the same way clientcmd.ClientConfigLoadingRules
+// merges $KUBECONFIG entries: the first config to define a given cluster/authInfo/context name
+// wins, and later sources can only fill in names earlier ones didn't already set. CurrentContext
+// likewise comes from the first config that sets it.
+func mergeConfigs(configs []*clientcmdapi.Config) *clientcmdapi.Config {
+	merged := clientcmdapi.NewConfig()
+	for _, cfg := range configs {
+		for name, cluster := range cfg.Clusters {
+			if _, exists := merged.Clusters[name]; !exists {
+				merged.Clusters[name] = cluster
+			}
+		}
+		for name, authInfo := range cfg.AuthInfos {
+			if _, exists := merged.AuthInfos[name]; !exists {
+				merged.AuthInfos[name] = authInfo
+			}
+		}
+		for name, context := range cfg.Contexts {
+			if _, exists := merged.Contexts[name]; !exists {
+				merged.Contexts[name] = context
+			}
+		}
+		if merged.CurrentContext == "" {
+			merged.CurrentContext = cfg.CurrentContext
+		}
+	}
+	return merged
+}
+
+// makeClientFromSources merges multiple kubeconfig sources (KUBECONFIG-style) into a single
+// config before applying authSettings overrides. This lets a shared cluster/CA block live in
+// one source while per-account user credentials come from another.
+func makeClientFromSources(ctx context.Context, sources []authSource, settings authSettings, spinCfg *interfaces.SpinnakerConfig) (*rest.Config, error) {
+	configs := make([]*clientcmdapi.Config, 0, len(sources))
+	for i, src := range sources {
+		cfg, err := src.loadRawConfig(ctx, spinCfg)
+		if err != nil {
+			return nil, fmt.Errorf("error loading auth source %d:\n  %w", i, err)
+		}
+		configs = append(configs, cfg)
+	}
+	merged := mergeConfigs(configs)
+	overrides, err := makeOverrideFromAuthSettings(merged, settings)
+	if err != nil {
+		return nil, err
+	}
+	restCfg, err := clientcmd.NewDefaultClientConfig(*merged, overrides).ClientConfig()
+	if err != nil {
+		return restCfg, fmt.Errorf("error building rest config from merged auth sources:\n  %w", err)
+	}
+	applyImpersonation(restCfg, settings)
+	return restCfg, nil
 }
 
 // makeClientFromSettings makes a client config from Spinnaker settings
@@ -159,12 +327,24 @@ func makeClientFromSettings(ctx context.Context, aSettings authSettings, spinCfg
 		if err != nil {
 			return nil, err
 		}
-		return clientcmd.NewDefaultClientConfig(*cfg, makeOverrideFromAuthSettings(cfg, aSettings)).ClientConfig()
+		overrides, err := makeOverrideFromAuthSettings(cfg, aSettings)
+		if err != nil {
+			return nil, err
+		}
+		restCfg, err := clientcmd.NewDefaultClientConfig(*cfg, overrides).ClientConfig()
+		if err != nil {
+			return restCfg, err
+		}
+		applyImpersonation(restCfg, aSettings)
+		return restCfg, nil
 	}
 	return nil, noValidKubeconfigError
 }
 
-func makeClientFromServiceAccount(ctx context.Context, spinSvc interfaces.SpinnakerService, c client.Client) (*rest.Config, error) {
+func makeClientFromServiceAccount(ctx context.Context, accountName string, spinSvc interfaces.SpinnakerService, c client.Client, settings authSettings) (*rest.Config, error) {
+	if settings.TLSBootstrap != nil {
+		return makeClientFromTLSBootstrap(ctx, accountName, spinSvc, c, settings)
+	}
 	spinSvc, err := ensureSpinSvc(spinSvc, c, ctx)
 	if err != nil {
 		return nil, err
@@ -187,11 +367,248 @@ func makeClientFromServiceAccount(ctx context.Context, spinSvc interfaces.Spinna
 	if err != nil {
 		return nil, err
 	}
-	return &rest.Config{
+	restCfg := &rest.Config{
 		Host:            apiHost,
 		TLSClientConfig: tlsClientConfig,
 		BearerToken:     token,
-	}, nil
+	}
+	applyImpersonation(restCfg, settings)
+	return restCfg, nil
+}
+
+// tlsBootstrapSettings configures a kubeadm-style CSR bootstrap flow for onboarding a remote
+// cluster that only exposes bootstrap-token auth, without pre-generating a long-lived
+// kubeconfig for it.
+type tlsBootstrapSettings struct {
+	BootstrapToken string `json:"bootstrapToken,omitempty"`
+	APIServer      string `json:"apiServer,omitempty"`
+	CAData         []byte `json:"caData,omitempty"`
+	// CertTTL is the requested validity of the issued client certificate, e.g. "24h"
+	CertTTL string `json:"certTTL,omitempty"`
+}
+
+// tlsBootstrapRenewalFraction is the fraction of a certificate's validity window after which
+// makeClientFromTLSBootstrap renews it, rather than waiting for it to expire outright.
+const tlsBootstrapRenewalFraction = 0.8
+
+// bootstrapSecretName is the Secret an account's bootstrapped client certificate/key is
+// persisted to, so subsequent reconciles reuse it instead of re-running the CSR flow. Account
+// names are sanitized into a valid Secret name (lowercased, then runs of invalid characters
+// collapsed to "-").
+func bootstrapSecretName(accountName string) string {
+	sanitized := invalidSecretNameChars.ReplaceAllString(strings.ToLower(accountName), "-")
+	return fmt.Sprintf("%s-tls-bootstrap", sanitized)
+}
+
+var invalidSecretNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// makeClientFromTLSBootstrap reuses a previously issued client certificate for accountName if
+// it is not yet within its renewal window, otherwise performs a CSR bootstrap against
+// settings.TLSBootstrap (analogous to kubeadm's PerformTLSBootstrap) and persists the result.
+func makeClientFromTLSBootstrap(ctx context.Context, accountName string, spinSvc interfaces.SpinnakerService, c client.Client, settings authSettings) (*rest.Config, error) {
+	tlsCfg := settings.TLSBootstrap
+	spinSvc, err := ensureSpinSvc(spinSvc, c, ctx)
+	if err != nil {
+		return nil, err
+	}
+	ns := bootstrapSecretNamespace(spinSvc)
+	certPEM, keyPEM, err := loadCachedBootstrapCert(ctx, c, ns, accountName)
+	if err != nil {
+		return nil, err
+	}
+	if certPEM == nil {
+		certPEM, keyPEM, err = performTLSBootstrap(ctx, accountName, tlsCfg)
+		if err != nil {
+			return nil, fmt.Errorf("error performing TLS bootstrap for account \"%s\":\n  %w", accountName, err)
+		}
+		if err := persistBootstrapCert(ctx, c, ns, accountName, certPEM, keyPEM); err != nil {
+			return nil, fmt.Errorf("error persisting bootstrapped TLS cert for account \"%s\":\n  %w", accountName, err)
+		}
+	}
+	restCfg := &rest.Config{
+		Host: tlsCfg.APIServer,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData:   tlsCfg.CAData,
+			CertData: certPEM,
+			KeyData:  keyPEM,
+		},
+	}
+	applyImpersonation(restCfg, settings)
+	return restCfg, nil
+}
+
+// bootstrapSecretNamespace returns the namespace bootstrapped certs are stashed in, falling
+// back to "default" when no SpinnakerService is available yet to validate against.
+func bootstrapSecretNamespace(spinSvc interfaces.SpinnakerService) string {
+	if spinSvc == nil {
+		return "default"
+	}
+	return spinSvc.GetNamespace()
+}
+
+// loadCachedBootstrapCert returns the cert/key persisted for accountName if they are not yet
+// within the renewal window, or (nil, nil, nil) if a fresh bootstrap is needed.
+func loadCachedBootstrapCert(ctx context.Context, c client.Client, namespace, accountName string) ([]byte, []byte, error) {
+	s := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: namespace, Name: bootstrapSecretName(accountName)}
+	if err := c.Get(ctx, key, s); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("error reading bootstrap TLS secret \"%s\":\n  %w", key.Name, err)
+	}
+	certPEM := s.Data[corev1.TLSCertKey]
+	keyPEM := s.Data[corev1.TLSPrivateKeyKey]
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return nil, nil, nil
+	}
+	cert, err := certutil.ParseCertsPEM(certPEM)
+	if err != nil || len(cert) == 0 {
+		return nil, nil, nil
+	}
+	validity := cert[0].NotAfter.Sub(cert[0].NotBefore)
+	renewAt := cert[0].NotBefore.Add(time.Duration(float64(validity) * tlsBootstrapRenewalFraction))
+	if time.Now().After(renewAt) {
+		return nil, nil, nil
+	}
+	return certPEM, keyPEM, nil
+}
+
+// persistBootstrapCert stores the issued cert/key in a Secret named after the account so
+// future reconciles reuse it until it needs renewal.
+func persistBootstrapCert(ctx context.Context, c client.Client, namespace, accountName string, certPEM, keyPEM []byte) error {
+	s := &corev1.Secret{
+		ObjectMeta: v13.ObjectMeta{Name: bootstrapSecretName(accountName), Namespace: namespace},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+	existing := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: s.Name}, existing)
+	if apierrors.IsNotFound(err) {
+		return c.Create(ctx, s)
+	}
+	if err != nil {
+		return err
+	}
+	existing.Data = s.Data
+	return c.Update(ctx, existing)
+}
+
+// performTLSBootstrap submits a CertificateSigningRequest for a client cert with
+// CN=spinnaker:<accountName>, O=system:spinnaker using the bootstrap token, then polls until
+// it is approved and issued, analogous to kubeadm's PerformTLSBootstrap.
+func performTLSBootstrap(ctx context.Context, accountName string, tlsCfg *tlsBootstrapSettings) ([]byte, []byte, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating bootstrap private key:\n  %w", err)
+	}
+	keyPEM, err := keyutil.MarshalPrivateKeyToPEM(privateKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error marshaling bootstrap private key:\n  %w", err)
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   fmt.Sprintf("spinnaker:%s", accountName),
+			Organization: []string{"system:spinnaker"},
+		},
+	}, privateKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating CSR:\n  %w", err)
+	}
+	// CertificateSigningRequestSpec.Request must be PEM-encoded; x509.CreateCertificateRequest
+	// only returns the DER bytes.
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+	bootstrapClient, err := kubernetes.NewForConfig(&rest.Config{
+		Host:        tlsCfg.APIServer,
+		BearerToken: tlsCfg.BootstrapToken,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: tlsCfg.CAData,
+		},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building bootstrap-token client:\n  %w", err)
+	}
+
+	var requestedDuration *time.Duration
+	if tlsCfg.CertTTL != "" {
+		ttl, err := time.ParseDuration(tlsCfg.CertTTL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid certTTL \"%s\":\n  %w", tlsCfg.CertTTL, err)
+		}
+		requestedDuration = &ttl
+	}
+	reqName, reqUID, err := certcsr.RequestCertificate(
+		bootstrapClient,
+		csrPEM,
+		"",
+		certificatesv1.KubeAPIServerClientSignerName,
+		requestedDuration,
+		[]certificatesv1.KeyUsage{certificatesv1.UsageClientAuth},
+		privateKey,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error submitting CSR:\n  %w", err)
+	}
+	certPEM, err := certcsr.WaitForCertificate(ctx, bootstrapClient, reqName, reqUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error waiting for CSR to be approved and issued:\n  %w", err)
+	}
+	return certPEM, keyPEM, nil
+}
+
+// vaultKubernetesSettings configures minting short-lived kubernetes credentials from a Vault
+// "kubernetes" secrets engine role instead of checking a long-lived kubeconfig into
+// spec.spinnakerConfig.files
+type vaultKubernetesSettings struct {
+	VaultAddr string `json:"vaultAddr,omitempty"`
+	AuthPath  string `json:"authPath,omitempty"`
+	// Role is the Vault auth/kubernetes role the operator pod logs in as
+	Role        string `json:"role,omitempty"`
+	SecretsPath string `json:"secretsPath,omitempty"`
+	// KubernetesNamespace overrides the namespace the minted ServiceAccount token is bound to;
+	// defaults to the role's own configuration in Vault when empty
+	KubernetesNamespace string `json:"kubernetesNamespace,omitempty"`
+	// TTL requested for the minted credential, e.g. "1h"; defaults to the role's configured TTL
+	TTL string `json:"ttl,omitempty"`
+	// ClusterHost and ClusterCAData supply the target cluster's API host/CA inline instead of
+	// reading them from the kubernetes secrets engine mount's own config
+	ClusterHost   string `json:"clusterHost,omitempty"`
+	ClusterCAData []byte `json:"clusterCAData,omitempty"`
+}
+
+// makeClientFromVaultKubernetes mints short-lived kubernetes credentials from Vault's
+// kubernetes secrets engine and builds a rest.Config from them, reusing a cached credential
+// until it is 80% through its TTL.
+func makeClientFromVaultKubernetes(ctx context.Context, accountName string, vCfg *vaultKubernetesSettings, settings authSettings) (*rest.Config, error) {
+	// Fold the Vault role (SecretsPath) into the cache key alongside the account name so two
+	// distinct roles configured for the same account never share a cached credential.
+	cacheKey := fmt.Sprintf("%s/%s", accountName, vCfg.SecretsPath)
+	creds, err := secrets.VaultClient().GetKubernetesCredentials(ctx, secrets.VaultKubernetesCredentialRequest{
+		CacheKey:      cacheKey,
+		VaultAddr:     vCfg.VaultAddr,
+		AuthPath:      vCfg.AuthPath,
+		Role:          vCfg.Role,
+		SecretsPath:   vCfg.SecretsPath,
+		Namespace:     vCfg.KubernetesNamespace,
+		TTL:           vCfg.TTL,
+		ClusterHost:   vCfg.ClusterHost,
+		ClusterCACert: vCfg.ClusterCAData,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error minting kubernetes credentials from vault for account \"%s\":\n  %w", accountName, err)
+	}
+	tlsClientConfig := rest.TLSClientConfig{CAData: creds.CACert}
+	restCfg := &rest.Config{
+		Host:            creds.Host,
+		TLSClientConfig: tlsClientConfig,
+		BearerToken:     creds.ServiceAccountToken,
+	}
+	applyImpersonation(restCfg, settings)
+	return restCfg, nil
 }
 
 func ensureSpinSvc(spinSvc interfaces.SpinnakerService, c client.Client, ctx context.Context) (interfaces.SpinnakerService, error) {
@@ -234,7 +651,7 @@ func getAPIServerHost() (string, error) {
 	return fmt.Sprintf("https://%s", net.JoinHostPort(host, port)), nil
 }
 
-func makeOverrideFromAuthSettings(config *clientcmdapi.Config, settings authSettings) *clientcmd.ConfigOverrides {
+func makeOverrideFromAuthSettings(config *clientcmdapi.Config, settings authSettings) (*clientcmd.ConfigOverrides, error) {
 	overrides := &clientcmd.ConfigOverrides{}
 	if settings.Context != "" {
 		overrides.CurrentContext = settings.Context
@@ -259,7 +676,76 @@ func makeOverrideFromAuthSettings(config *clientcmdapi.Config, settings authSett
 			},
 		}
 	}
-	return overrides
+	if settings.Exec != nil {
+		execCfg, err := settings.Exec.toExecConfig()
+		if err != nil {
+			return nil, err
+		}
+		overrides.AuthInfo.Exec = execCfg
+	}
+	return overrides, nil
+}
+
+// execCredentialAllowlistEnvVar names the env var holding a comma-separated list of exec
+// commands the operator pod is permitted to fork on behalf of an account. Plugin execution
+// inside the operator is a privilege boundary, so unlike every other auth mode it is opt-in.
+const execCredentialAllowlistEnvVar = "ACCOUNT_EXEC_CREDENTIAL_ALLOWLIST"
+
+// execSettings configures a client.authentication.k8s.io exec-credential plugin
+// (aws-iam-authenticator, gcloud, pinniped login, etc.) as the account's auth source.
+type execSettings struct {
+	Command     string            `json:"command,omitempty"`
+	Args        []string          `json:"args,omitempty"`
+	Env         map[string]string `json:"env,omitempty"`
+	APIVersion  string            `json:"apiVersion,omitempty"`
+	InstallHint string            `json:"installHint,omitempty"`
+	// InteractiveMode mirrors clientcmdapi's ExecInteractiveMode ("Never", "IfAvailable",
+	// "Always"); defaults to "Never" since the operator pod has no terminal to prompt on.
+	InteractiveMode string `json:"interactiveMode,omitempty"`
+}
+
+func (e *execSettings) toExecConfig() (*clientcmdapi.ExecConfig, error) {
+	if !isExecCommandAllowed(e.Command) {
+		return nil, fmt.Errorf("exec command \"%s\" is not in the %s allowlist", e.Command, execCredentialAllowlistEnvVar)
+	}
+	apiVersion := e.APIVersion
+	if apiVersion == "" {
+		apiVersion = "client.authentication.k8s.io/v1beta1"
+	}
+	mode := clientcmdapi.ExecInteractiveMode(e.InteractiveMode)
+	if mode == "" {
+		mode = clientcmdapi.NeverExecInteractiveMode
+	}
+	envVars := make([]clientcmdapi.ExecEnvVar, 0, len(e.Env))
+	for name, value := range e.Env {
+		envVars = append(envVars, clientcmdapi.ExecEnvVar{Name: name, Value: value})
+	}
+	// Token caching keyed by cluster+exec config (and its expirationTimestamp) is handled
+	// in-process by client-go's exec credential plugin cache, so we don't fork a subprocess
+	// per reconcile.
+	return &clientcmdapi.ExecConfig{
+		Command:         e.Command,
+		Args:            e.Args,
+		Env:             envVars,
+		APIVersion:      apiVersion,
+		InstallHint:     e.InstallHint,
+		InteractiveMode: mode,
+	}, nil
+}
+
+// isExecCommandAllowed checks cmd against the comma-separated execCredentialAllowlistEnvVar.
+// Exec plugins are disabled entirely (empty allowlist) unless an operator opts in explicitly.
+func isExecCommandAllowed(cmd string) bool {
+	allowlist := os.Getenv(execCredentialAllowlistEnvVar)
+	if allowlist == "" {
+		return false
+	}
+	for _, allowed := range strings.Split(allowlist, ",") {
+		if strings.TrimSpace(allowed) == cmd {
+			return true
+		}
+	}
+	return false
 }
 
 type authSettings struct {
@@ -275,6 +761,41 @@ type authSettings struct {
 	KubeconfigContents  string   `json:"kubeconfigContents,omitempty"`
 	OAuthServiceAccount string   `json:"oAuthServiceAccount,omitempty"`
 	OAuthScopes         []string `json:"oAuthScopes,omitempty"`
+	// Exec configures a client.authentication.k8s.io exec-credential plugin as the auth source
+	Exec *execSettings `json:"exec,omitempty"`
+	// Impersonate configures the identity the underlying rest.Config acts as, letting a single
+	// privileged kubeconfig/service account validate as a per-account identity; it is not
+	// written into the kubeconfig clouddriver uses (see applyImpersonation)
+	Impersonate *impersonateSettings `json:"impersonate,omitempty"`
+	// TLSBootstrap configures a CSR bootstrap flow for useServiceAccount accounts targeting a
+	// remote cluster that only exposes bootstrap-token auth
+	TLSBootstrap *tlsBootstrapSettings `json:"tlsBootstrap,omitempty"`
+}
+
+// impersonateSettings maps to client-go's rest.ImpersonationConfig
+type impersonateSettings struct {
+	User   string              `json:"user,omitempty"`
+	Groups []string            `json:"groups,omitempty"`
+	UID    string              `json:"uid,omitempty"`
+	Extra  map[string][]string `json:"extra,omitempty"`
+}
+
+// applyImpersonation sets cfg.Impersonate from settings.Impersonate, if configured. It is
+// applied last, after the config is otherwise fully built, so every auth mode ends up acting
+// as the same impersonated identity regardless of how the underlying credentials were sourced.
+// This only affects the *rest.Config the operator itself uses to validate the account; it does
+// not propagate to the kubeconfig clouddriver is handed to serve the account, since this
+// package only builds the operator's own validation client.
+func applyImpersonation(cfg *rest.Config, settings authSettings) {
+	if settings.Impersonate == nil {
+		return
+	}
+	cfg.Impersonate = rest.ImpersonationConfig{
+		UserName: settings.Impersonate.User,
+		Groups:   settings.Impersonate.Groups,
+		UID:      settings.Impersonate.UID,
+		Extra:    settings.Impersonate.Extra,
+	}
 }
 
 func (k *kubernetesAccountValidator) validateAccess(ctx context.Context, cc *rest.Config) error {
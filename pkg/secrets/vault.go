@@ -0,0 +1,228 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// projectedServiceAccountTokenPath is the default location Kubernetes mounts the pod's own
+// projected ServiceAccount token, reused to authenticate to Vault's kubernetes auth method.
+const projectedServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// VaultKubernetesCredentialRequest describes a kubernetes secrets-engine role to mint a
+// short-lived ServiceAccount token from.
+type VaultKubernetesCredentialRequest struct {
+	// CacheKey identifies this request for the purposes of credential reuse; callers should
+	// fold both the account and the Vault role (SecretsPath) into it so two roles used by the
+	// same account never share a cached credential
+	CacheKey    string
+	VaultAddr   string
+	AuthPath    string
+	Role        string
+	SecretsPath string
+	Namespace   string
+	TTL         string
+	// ClusterHost and ClusterCACert are used instead of a Vault-hosted cluster config when set
+	ClusterHost   string
+	ClusterCACert []byte
+}
+
+// VaultKubernetesCredentials is a minted, short-lived kubernetes credential
+type VaultKubernetesCredentials struct {
+	ServiceAccountToken string
+	CACert              []byte
+	Host                string
+	expiresAt           time.Time
+}
+
+type vaultKubernetesClient struct {
+	mu         sync.Mutex
+	cache      map[string]*VaultKubernetesCredentials
+	httpClient *http.Client
+}
+
+var defaultVaultClient = &vaultKubernetesClient{
+	cache:      map[string]*VaultKubernetesCredentials{},
+	httpClient: &http.Client{Timeout: 10 * time.Second},
+}
+
+// VaultClient returns the package-wide Vault client used to mint and cache dynamic kubernetes
+// credentials.
+func VaultClient() *vaultKubernetesClient {
+	return defaultVaultClient
+}
+
+// GetKubernetesCredentials returns a cached credential for req.CacheKey if it is not yet 80%
+// through its TTL, otherwise it logs into Vault with the pod's own projected ServiceAccount
+// token and mints a fresh one from req.SecretsPath.
+func (v *vaultKubernetesClient) GetKubernetesCredentials(ctx context.Context, req VaultKubernetesCredentialRequest) (*VaultKubernetesCredentials, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if cached, ok := v.cache[req.CacheKey]; ok && time.Now().Before(cached.expiresAt) {
+		return cached, nil
+	}
+
+	loginToken, err := v.login(ctx, req.VaultAddr, req.AuthPath, req.Role)
+	if err != nil {
+		return nil, fmt.Errorf("error logging into vault at \"%s\":\n  %w", req.AuthPath, err)
+	}
+	creds, err := v.readKubernetesCreds(ctx, req, loginToken)
+	if err != nil {
+		return nil, fmt.Errorf("error reading kubernetes creds from \"%s\":\n  %w", req.SecretsPath, err)
+	}
+	v.cache[req.CacheKey] = creds
+	return creds, nil
+}
+
+// vaultLoginResponse is the subset of Vault's auth/kubernetes/login response we need
+type vaultLoginResponse struct {
+	Auth struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+}
+
+// login authenticates to Vault's kubernetes auth method by POSTing the pod's own projected
+// ServiceAccount token and the configured role, returning the resulting Vault client token.
+func (v *vaultKubernetesClient) login(ctx context.Context, vaultAddr, authPath, role string) (string, error) {
+	saToken, err := ioutil.ReadFile(projectedServiceAccountTokenPath)
+	if err != nil {
+		return "", fmt.Errorf("error reading projected service account token:\n  %w", err)
+	}
+	body, err := json.Marshal(map[string]string{"jwt": string(saToken), "role": role})
+	if err != nil {
+		return "", err
+	}
+	var loginResp vaultLoginResponse
+	if err := v.doRequest(ctx, http.MethodPost, vaultAddr, authPath, "", body, &loginResp); err != nil {
+		return "", err
+	}
+	if loginResp.Auth.ClientToken == "" {
+		return "", fmt.Errorf("vault login returned an empty client token")
+	}
+	return loginResp.Auth.ClientToken, nil
+}
+
+// vaultKubernetesCredsResponse is the subset of the kubernetes secrets engine's creds response
+// we need
+type vaultKubernetesCredsResponse struct {
+	LeaseDuration int `json:"lease_duration"`
+	Data          struct {
+		ServiceAccountToken string `json:"service_account_token"`
+	} `json:"data"`
+}
+
+// vaultKubernetesConfigResponse is the subset of the kubernetes secrets engine's mount config
+// we need to build a rest.Config when ClusterHost/ClusterCACert aren't supplied inline
+type vaultKubernetesConfigResponse struct {
+	Data struct {
+		KubernetesHost   string `json:"kubernetes_host"`
+		KubernetesCACert string `json:"kubernetes_ca_cert"`
+	} `json:"data"`
+}
+
+// readKubernetesCreds calls the kubernetes secrets engine at req.SecretsPath to mint a
+// short-lived ServiceAccount token, computing expiresAt as 80% of the returned lease duration
+// so callers renew before Vault revokes the credential out from under them. The cluster
+// host/CA come from req.ClusterHost/req.ClusterCACert when set, otherwise from the secrets
+// engine mount's own config.
+func (v *vaultKubernetesClient) readKubernetesCreds(ctx context.Context, req VaultKubernetesCredentialRequest, loginToken string) (*VaultKubernetesCredentials, error) {
+	credsParams := map[string]string{}
+	if req.Namespace != "" {
+		credsParams["kubernetes_namespace"] = req.Namespace
+	}
+	if req.TTL != "" {
+		credsParams["ttl"] = req.TTL
+	}
+	credsBody, err := json.Marshal(credsParams)
+	if err != nil {
+		return nil, err
+	}
+	var credsResp vaultKubernetesCredsResponse
+	if err := v.doRequest(ctx, http.MethodPost, req.VaultAddr, req.SecretsPath, loginToken, credsBody, &credsResp); err != nil {
+		return nil, err
+	}
+	if credsResp.Data.ServiceAccountToken == "" {
+		return nil, fmt.Errorf("vault returned no service_account_token")
+	}
+
+	host := req.ClusterHost
+	caCert := req.ClusterCACert
+	if host == "" || len(caCert) == 0 {
+		var cfgResp vaultKubernetesConfigResponse
+		if err := v.doRequest(ctx, http.MethodGet, req.VaultAddr, mountConfigPath(req.SecretsPath), loginToken, nil, &cfgResp); err != nil {
+			return nil, fmt.Errorf("error reading cluster host/CA from vault mount config:\n  %w", err)
+		}
+		if host == "" {
+			host = cfgResp.Data.KubernetesHost
+		}
+		if len(caCert) == 0 {
+			caCert = []byte(cfgResp.Data.KubernetesCACert)
+		}
+	}
+
+	leaseDuration := time.Duration(credsResp.LeaseDuration) * time.Second
+	if leaseDuration == 0 && req.TTL != "" {
+		if parsed, err := time.ParseDuration(req.TTL); err == nil {
+			leaseDuration = parsed
+		}
+	}
+	if leaseDuration == 0 {
+		leaseDuration = time.Hour
+	}
+	return &VaultKubernetesCredentials{
+		ServiceAccountToken: credsResp.Data.ServiceAccountToken,
+		CACert:              caCert,
+		Host:                host,
+		expiresAt:           time.Now().Add(leaseDuration * 8 / 10),
+	}, nil
+}
+
+// mountConfigPath derives the kubernetes secrets engine mount's config endpoint from a creds
+// path, e.g. "kubernetes/creds/my-role" -> "kubernetes/config".
+func mountConfigPath(secretsPath string) string {
+	mount := strings.SplitN(secretsPath, "/", 2)[0]
+	return path.Join(mount, "config")
+}
+
+// doRequest issues a single Vault HTTP API call and decodes its JSON response into out.
+func (v *vaultKubernetesClient) doRequest(ctx context.Context, method, vaultAddr, apiPath, token string, body []byte, out interface{}) error {
+	url := strings.TrimSuffix(vaultAddr, "/") + "/v1/" + strings.TrimPrefix(apiPath, "/")
+	var bodyReader *strings.Reader
+	if body != nil {
+		bodyReader = strings.NewReader(string(body))
+	} else {
+		bodyReader = strings.NewReader("")
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("vault request to \"%s\" failed with status %d: %s", apiPath, resp.StatusCode, string(respBody))
+	}
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(respBody, out)
+}